@@ -4,13 +4,65 @@ import (
 	"fmt"
 
 	resourceapi "k8s.io/api/resource/v1alpha3"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
 	"k8s.io/kubernetes/pkg/scheduler/util/assumecache"
 )
 
+// Index names registered on the ResourceClaim assume cache. See
+// ClaimsAssumeCache.ListAllocatedOnNode and ClaimsAssumeCache.ListByDeviceClass.
+const (
+	claimNodeNameIndex    = "dra-claim-node-name"
+	claimDeviceClassIndex = "dra-claim-device-class"
+)
+
 type ClaimsAssumeCache struct {
 	cache *assumecache.AssumeCache
 }
 
+// NewClaimsAssumeCache registers the node-name and device-class indexes this
+// type's indexed lookups depend on and returns a ClaimsAssumeCache wrapping
+// claimsCache. It mirrors claimTracker's construction in
+// pkg/scheduler/framework/runtime/dra_manager.go, which the same indexes were
+// first added to.
+func NewClaimsAssumeCache(claimsCache *assumecache.AssumeCache) (*ClaimsAssumeCache, error) {
+	if err := claimsCache.AddIndexers(cache.Indexers{
+		claimNodeNameIndex:    claimNodeNameIndexFunc,
+		claimDeviceClassIndex: claimDeviceClassIndexFunc,
+	}); err != nil {
+		return nil, fmt.Errorf("adding indexers to claims assume cache: %w", err)
+	}
+	return &ClaimsAssumeCache{cache: claimsCache}, nil
+}
+
+// claimNodeNameIndexFunc indexes ResourceClaims by the node name(s) their
+// allocation is pinned to, so ListAllocatedOnNode doesn't have to walk every
+// claim in the cluster. It delegates to dra.AllocationResult.NodeNames so the
+// "only a fixed node set, not a label selector" handling isn't duplicated.
+func claimNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok || claim.Status.Allocation == nil {
+		return nil, nil
+	}
+	allocation := &dra.AllocationResult{NodeSelector: claim.Status.Allocation.NodeSelector}
+	return allocation.NodeNames(), nil
+}
+
+// claimDeviceClassIndexFunc indexes ResourceClaims by the device class(es)
+// their spec requests, so ListByDeviceClass doesn't have to walk every claim
+// in the cluster.
+func claimDeviceClassIndexFunc(obj interface{}) ([]string, error) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		return nil, nil
+	}
+	var classes []string
+	for _, req := range claim.Spec.Devices.Requests {
+		classes = append(classes, req.DeviceClassName)
+	}
+	return classes, nil
+}
+
 func (c *ClaimsAssumeCache) Get(namespace, claimName string) (*resourceapi.ResourceClaim, error) {
 	obj, err := c.cache.Get(namespace + "/" + claimName)
 	if err != nil {
@@ -35,9 +87,11 @@ func (c *ClaimsAssumeCache) GetOriginal(namespace, claimName string) (*resourcea
 	return claim, nil
 }
 
+// List returns every claim in the cache. There is no narrower index to
+// iterate here: it is asking for all claims, not a subset keyed by node name
+// or device class, so there's nothing to filter the full scan down to.
 func (c *ClaimsAssumeCache) List() ([]*resourceapi.ResourceClaim, error) {
 	var result []*resourceapi.ResourceClaim
-	// Probably not worth adding an index for?
 	objs := c.cache.List(nil)
 	for _, obj := range objs {
 		claim, ok := obj.(*resourceapi.ResourceClaim)
@@ -48,6 +102,40 @@ func (c *ClaimsAssumeCache) List() ([]*resourceapi.ResourceClaim, error) {
 	return result, nil
 }
 
+// ListAllocatedOnNode returns the allocated claims whose allocation is
+// pinned to nodeName, using the claimNodeNameIndex index instead of scanning
+// every claim in the cache.
+func (c *ClaimsAssumeCache) ListAllocatedOnNode(nodeName string) ([]*resourceapi.ResourceClaim, error) {
+	objs, err := c.cache.ByIndex(claimNodeNameIndex, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*resourceapi.ResourceClaim, 0, len(objs))
+	for _, obj := range objs {
+		if claim, ok := obj.(*resourceapi.ResourceClaim); ok {
+			result = append(result, claim)
+		}
+	}
+	return result, nil
+}
+
+// ListByDeviceClass returns the claims whose spec requests deviceClassName,
+// using the claimDeviceClassIndex index instead of scanning every claim in
+// the cache.
+func (c *ClaimsAssumeCache) ListByDeviceClass(deviceClassName string) ([]*resourceapi.ResourceClaim, error) {
+	objs, err := c.cache.ByIndex(claimDeviceClassIndex, deviceClassName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*resourceapi.ResourceClaim, 0, len(objs))
+	for _, obj := range objs {
+		if claim, ok := obj.(*resourceapi.ResourceClaim); ok {
+			result = append(result, claim)
+		}
+	}
+	return result, nil
+}
+
 func (c *ClaimsAssumeCache) Assume(claim *resourceapi.ResourceClaim) error {
 	return c.cache.Assume(claim)
 }
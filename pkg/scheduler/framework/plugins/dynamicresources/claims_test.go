@@ -0,0 +1,62 @@
+package dynamicresources
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestClaimIndexFuncs(t *testing.T) {
+	pinned := &resourceapi.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pinned"},
+		Spec: resourceapi.ResourceClaimSpec{
+			Devices: resourceapi.DeviceClaim{Requests: []resourceapi.DeviceRequest{{DeviceClassName: "gpu"}}},
+		},
+		Status: resourceapi.ResourceClaimStatus{
+			Allocation: &resourceapi.AllocationResult{
+				NodeSelector: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{{
+						MatchFields: []v1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-a"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+	unallocated := &resourceapi.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "unallocated"},
+		Spec: resourceapi.ResourceClaimSpec{
+			Devices: resourceapi.DeviceClaim{Requests: []resourceapi.DeviceRequest{{DeviceClassName: "gpu"}}},
+		},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		claimNodeNameIndex:    claimNodeNameIndexFunc,
+		claimDeviceClassIndex: claimDeviceClassIndexFunc,
+	})
+	for _, claim := range []*resourceapi.ResourceClaim{pinned, unallocated} {
+		if err := indexer.Add(claim); err != nil {
+			t.Fatalf("adding claim to indexer: %v", err)
+		}
+	}
+
+	byNode, err := indexer.ByIndex(claimNodeNameIndex, "node-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(byNode) != 1 || byNode[0].(*resourceapi.ResourceClaim).Name != "pinned" {
+		t.Fatalf("ByIndex(claimNodeNameIndex, node-a) = %v, want just [pinned]", byNode)
+	}
+
+	byClass, err := indexer.ByIndex(claimDeviceClassIndex, "gpu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(byClass) != 2 {
+		t.Fatalf("ByIndex(claimDeviceClassIndex, gpu) = %v, want both claims", byClass)
+	}
+}
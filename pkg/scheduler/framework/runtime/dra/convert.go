@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// toObjectReference builds the trimmed v1.ObjectReference used in
+// Claim.ReservedFor from the consumer reference fields both API versions
+// expose.
+func toObjectReference(namespace, name string, uid types.UID) v1.ObjectReference {
+	return v1.ObjectReference{
+		Namespace: namespace,
+		Name:      name,
+		UID:       uid,
+	}
+}
+
+// Converter translates between a single resource.k8s.io API version and the
+// neutral types in this package. DraManager picks exactly one Converter at
+// construction time, based on what the API server it talks to supports.
+type Converter interface {
+	// Version returns the API version this Converter handles.
+	Version() APIVersion
+
+	// ToClaim converts an API-version-specific ResourceClaim (passed as
+	// interface{} so one method signature covers the claim types of both
+	// versions) into its neutral representation. ok is false if obj is not a
+	// ResourceClaim this converter understands.
+	ToClaim(obj interface{}) (claim *Claim, ok bool)
+
+	// ToSlice converts an API-version-specific ResourceSlice.
+	ToSlice(obj interface{}) (slice *Slice, ok bool)
+
+	// ToDeviceClass converts an API-version-specific DeviceClass or
+	// ResourceClass.
+	ToDeviceClass(obj interface{}) (class *DeviceClass, ok bool)
+
+	// ToPodSchedulingContext converts an API-version-specific
+	// PodSchedulingContext. v1alpha3 has no such object, so its converter
+	// always returns ok=false.
+	ToPodSchedulingContext(obj interface{}) (pcs *PodSchedulingContext, ok bool)
+}
+
+// ConverterFor returns the Converter for the given negotiated API version.
+func ConverterFor(version APIVersion) (Converter, error) {
+	switch version {
+	case APIVersionV1alpha2:
+		return v1alpha2Converter{}, nil
+	case APIVersionV1alpha3:
+		return v1alpha3Converter{}, nil
+	default:
+		return nil, unsupportedVersionError(version)
+	}
+}
+
+type unsupportedVersionError APIVersion
+
+func (e unsupportedVersionError) Error() string {
+	return "unsupported DRA API version: " + string(e)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	resourceapi "k8s.io/api/resource/v1alpha2"
+)
+
+// v1alpha2Converter translates the older resource.k8s.io/v1alpha2 objects
+// (ResourceClass, ResourceClaim, ResourceClaimTemplate and
+// PodSchedulingContext) into the neutral types in this package. It exists so
+// DraManager keeps working against clusters that have not yet upgraded to
+// v1alpha3.
+type v1alpha2Converter struct{}
+
+func (v1alpha2Converter) Version() APIVersion {
+	return APIVersionV1alpha2
+}
+
+func (v1alpha2Converter) ToClaim(obj interface{}) (*Claim, bool) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		return nil, false
+	}
+	result := &Claim{
+		UID:       claim.UID,
+		Namespace: claim.Namespace,
+		Name:      claim.Name,
+	}
+	if claim.Spec.ResourceClassName != "" {
+		result.RequestedDeviceClasses = []string{claim.Spec.ResourceClassName}
+	}
+	if claim.Status.Allocation != nil {
+		devices := make([]string, 0, len(claim.Status.Allocation.ResourceHandles))
+		for _, h := range claim.Status.Allocation.ResourceHandles {
+			devices = append(devices, h.DriverName+"/"+h.Data)
+		}
+		result.Allocation = &AllocationResult{
+			NodeSelector: claim.Status.Allocation.AvailableOnNodes,
+			Devices:      devices,
+		}
+	}
+	for _, ref := range claim.Status.ReservedFor {
+		result.ReservedFor = append(result.ReservedFor, toObjectReference(claim.Namespace, ref.Name, ref.UID))
+	}
+	return result, true
+}
+
+func (v1alpha2Converter) ToSlice(obj interface{}) (*Slice, bool) {
+	// v1alpha2 has no ResourceSlice equivalent: device inventory was
+	// published inline on ResourceClass/PodSchedulingContext instead.
+	return nil, false
+}
+
+func (v1alpha2Converter) ToDeviceClass(obj interface{}) (*DeviceClass, bool) {
+	class, ok := obj.(*resourceapi.ResourceClass)
+	if !ok {
+		return nil, false
+	}
+	return &DeviceClass{Name: class.Name}, true
+}
+
+func (v1alpha2Converter) ToPodSchedulingContext(obj interface{}) (*PodSchedulingContext, bool) {
+	pcs, ok := obj.(*resourceapi.PodSchedulingContext)
+	if !ok {
+		return nil, false
+	}
+	result := &PodSchedulingContext{
+		Namespace:    pcs.Namespace,
+		PodName:      pcs.Name,
+		SelectedNode: pcs.Spec.SelectedNode,
+	}
+	for _, c := range pcs.Status.ResourceClaims {
+		result.ResourceClaims = append(result.ResourceClaims, ResourceClaimSchedulingStatus{
+			Name:            c.Name,
+			UnsuitableNodes: c.UnsuitableNodes,
+		})
+	}
+	return result, true
+}
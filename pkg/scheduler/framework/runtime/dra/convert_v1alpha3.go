@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	resourceapi "k8s.io/api/resource/v1alpha3"
+)
+
+// v1alpha3Converter translates resource.k8s.io/v1alpha3 objects into the
+// neutral types in this package.
+type v1alpha3Converter struct{}
+
+func (v1alpha3Converter) Version() APIVersion {
+	return APIVersionV1alpha3
+}
+
+func (v1alpha3Converter) ToClaim(obj interface{}) (*Claim, bool) {
+	claim, ok := obj.(*resourceapi.ResourceClaim)
+	if !ok {
+		return nil, false
+	}
+	result := &Claim{
+		UID:       claim.UID,
+		Namespace: claim.Namespace,
+		Name:      claim.Name,
+	}
+	for _, req := range claim.Spec.Devices.Requests {
+		result.RequestedDeviceClasses = append(result.RequestedDeviceClasses, req.DeviceClassName)
+	}
+	if claim.Status.Allocation != nil {
+		devices := make([]string, 0, len(claim.Status.Allocation.Devices.Results))
+		for _, r := range claim.Status.Allocation.Devices.Results {
+			devices = append(devices, r.Driver+"/"+r.Pool+"/"+r.Device)
+		}
+		result.Allocation = &AllocationResult{
+			NodeSelector: claim.Status.Allocation.NodeSelector,
+			Devices:      devices,
+		}
+	}
+	for _, ref := range claim.Status.ReservedFor {
+		result.ReservedFor = append(result.ReservedFor, toObjectReference(claim.Namespace, ref.Name, ref.UID))
+	}
+	return result, true
+}
+
+func (v1alpha3Converter) ToSlice(obj interface{}) (*Slice, bool) {
+	slice, ok := obj.(*resourceapi.ResourceSlice)
+	if !ok {
+		return nil, false
+	}
+	return &Slice{
+		Name:       slice.Name,
+		DriverName: slice.Spec.Driver,
+		NodeName:   slice.Spec.NodeName,
+	}, true
+}
+
+func (v1alpha3Converter) ToDeviceClass(obj interface{}) (*DeviceClass, bool) {
+	class, ok := obj.(*resourceapi.DeviceClass)
+	if !ok {
+		return nil, false
+	}
+	return &DeviceClass{Name: class.Name}, true
+}
+
+func (v1alpha3Converter) ToPodSchedulingContext(obj interface{}) (*PodSchedulingContext, bool) {
+	// v1alpha3 dropped PodSchedulingContext: WaitForFirstConsumer
+	// coordination now happens entirely through DeviceAllocationConfiguration.
+	return nil, false
+}
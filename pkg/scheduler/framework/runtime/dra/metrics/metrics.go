@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the Prometheus metrics for the scheduler framework's
+// DraManager (pkg/scheduler/framework/runtime), following the pattern set by
+// pkg/controller/resourceclaim/metrics.
+package metrics
+
+import (
+	"sync"
+
+	k8smetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var (
+	// ClaimAssumeTotal counts successful AssumeClaimAfterApiCall calls.
+	ClaimAssumeTotal = k8smetrics.NewCounter(&k8smetrics.CounterOpts{
+		Name:           "dra_claim_assume_total",
+		Help:           "Number of times a ResourceClaim was assumed into the DRA claims cache after a successful API call.",
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimAssumeRestoreTotal counts AssumedClaimRestore calls, i.e. an
+	// assumed claim being rolled back because the API call that was
+	// supposed to persist it failed.
+	ClaimAssumeRestoreTotal = k8smetrics.NewCounter(&k8smetrics.CounterOpts{
+		Name:           "dra_claim_assume_restore_total",
+		Help:           "Number of times an assumed ResourceClaim was restored to its pre-assume state after a failed API call.",
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimPendingAllocationSignaledTotal counts
+	// SignalClaimPendingAllocation calls.
+	ClaimPendingAllocationSignaledTotal = k8smetrics.NewCounter(&k8smetrics.CounterOpts{
+		Name:           "dra_claim_pending_allocation_signaled_total",
+		Help:           "Number of times a claim allocation was signaled as pending, ahead of the PreBind API call that persists it.",
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimPendingAllocationRemovedTotal counts RemoveClaimPendingAllocation
+	// calls that actually found and removed an entry.
+	ClaimPendingAllocationRemovedTotal = k8smetrics.NewCounter(&k8smetrics.CounterOpts{
+		Name:           "dra_claim_pending_allocation_removed_total",
+		Help:           "Number of times a pending claim allocation was removed, usually once the informer observed the persisted allocation.",
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimPendingAllocations is a gauge of the current size of
+	// claimTracker.inFlightAllocations.
+	ClaimPendingAllocations = k8smetrics.NewGauge(&k8smetrics.GaugeOpts{
+		Name:           "dra_claim_pending_allocations",
+		Help:           "Current number of claim allocations signaled as pending but not yet observed through the informer.",
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimPendingAllocationDuration measures the time between
+	// SignalClaimPendingAllocation and RemoveClaimPendingAllocation for a
+	// given claim, i.e. how long a PreBind stall lasted.
+	ClaimPendingAllocationDuration = k8smetrics.NewHistogram(&k8smetrics.HistogramOpts{
+		Name:           "dra_claim_pending_allocation_duration_seconds",
+		Help:           "Time between a claim allocation being signaled as pending and its removal, in seconds.",
+		Buckets:        k8smetrics.ExponentialBuckets(0.001, 2, 15),
+		StabilityLevel: k8smetrics.ALPHA,
+	})
+	// ClaimCacheMissTotal counts Get/GetOriginal calls that returned an
+	// error from the assume cache, broken down by which of the two methods
+	// missed.
+	ClaimCacheMissTotal = k8smetrics.NewCounterVec(&k8smetrics.CounterOpts{
+		Name:           "dra_claim_cache_miss_total",
+		Help:           "Number of ResourceClaimTracker Get/GetOriginal calls that missed the assume cache, by method.",
+		StabilityLevel: k8smetrics.ALPHA,
+	}, []string{"method"})
+)
+
+var registerMetrics sync.Once
+
+// Register registers the DraManager metrics with the legacy registry. It is
+// safe to call more than once, including concurrently: registration only
+// happens the first time. Callers that construct multiple DraManagers (e.g.
+// in tests) should still only reach this through
+// runtime.NewDraManagerWithMetrics, which is the one documented,
+// double-registration-safe entry point.
+func Register() {
+	registerMetrics.Do(func() {
+		legacyregistry.MustRegister(
+			ClaimAssumeTotal,
+			ClaimAssumeRestoreTotal,
+			ClaimPendingAllocationSignaledTotal,
+			ClaimPendingAllocationRemovedTotal,
+			ClaimPendingAllocations,
+			ClaimPendingAllocationDuration,
+			ClaimCacheMissTotal,
+		)
+	})
+}
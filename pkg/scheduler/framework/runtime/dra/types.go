@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dra holds version-neutral representations of the DRA API objects
+// that the scheduler framework and cluster-autoscaler need to reason about.
+//
+// The resource.k8s.io API moved from v1alpha2 to v1alpha3 with a substantial
+// reshape of the allocation result and PodSchedulingContext wiring. Rather
+// than hard-wiring every consumer of DraManager to one API version, we
+// convert both versions into these neutral types at the edges (the informer
+// event handlers and the listers) so the rest of the scheduler framework
+// only ever has to deal with one shape.
+package dra
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// APIVersion identifies which generation of the resource.k8s.io API a
+// DraManager has been negotiated against.
+type APIVersion string
+
+const (
+	// APIVersionV1alpha2 is the pre-1.31 DRA API: ResourceClass,
+	// ResourceClaim, ResourceClaimTemplate and PodSchedulingContext.
+	APIVersionV1alpha2 APIVersion = "v1alpha2"
+	// APIVersionV1alpha3 is the current DRA API, which replaces
+	// ResourceClass with DeviceClass and reshapes allocation results around
+	// structured parameters.
+	APIVersionV1alpha3 APIVersion = "v1alpha3"
+)
+
+// Claim is a version-neutral view of a ResourceClaim, carrying only the
+// fields DraManager's consumers need regardless of which API version
+// produced it.
+type Claim struct {
+	UID       types.UID
+	Namespace string
+	Name      string
+
+	// Allocation is non-nil once the claim has been allocated, either by
+	// v1alpha2's AllocationResult or v1alpha3's equivalent.
+	Allocation *AllocationResult
+
+	// ForeignAllocation is true if Allocation was observed to have been set
+	// by something other than our own scheduling cycle (a device driver,
+	// another scheduler, or a manual edit), as opposed to a PreBind we
+	// triggered ourselves via claimTracker.SignalClaimPendingAllocation.
+	// cluster-autoscaler uses this to decide whether an allocation is a
+	// hard, non-relocatable reservation when simulating scale-down.
+	ForeignAllocation bool
+
+	// ReservedFor mirrors ResourceClaimStatus.ReservedFor, trimmed to the
+	// consumer references callers actually key off of.
+	ReservedFor []v1.ObjectReference
+
+	// RequestedDeviceClasses lists the device class names the claim's spec
+	// requests, taken from v1alpha3's per-request DeviceClassName or
+	// v1alpha2's single Spec.ResourceClassName. Used to index claims by
+	// device class without re-parsing the version-specific spec.
+	RequestedDeviceClasses []string
+}
+
+// AllocationResult is the version-neutral shape of an allocated claim: which
+// node(s) it is pinned to and which devices it consumes.
+type AllocationResult struct {
+	// NodeSelector mirrors AllocationResult.NodeSelector/AvailableOnNodes:
+	// nil means the allocation is available everywhere.
+	NodeSelector *v1.NodeSelector
+	// Devices lists the fully-qualified device names this claim consumes,
+	// formatted "<driver>/<pool>/<device>" for v1alpha3. v1alpha2
+	// ResourceHandles are translated into one "<driver>/<data>" entry per
+	// handle.
+	Devices []string
+}
+
+// nodeNameField is the field selector key both API versions use in
+// NodeSelectorTerm.MatchFields to pin an allocation to one specific node.
+const nodeNameField = "metadata.name"
+
+// NodeNames extracts the exact node names an AllocationResult's NodeSelector
+// is pinned to, if any. A nil result (as opposed to an empty, non-nil slice)
+// means the selector does not pin the allocation to a fixed set of nodes
+// (e.g. it uses a label selector), so callers indexing by node name should
+// treat the claim as not resolvable to a single node.
+func (a *AllocationResult) NodeNames() []string {
+	if a == nil || a.NodeSelector == nil {
+		return nil
+	}
+	var names []string
+	for _, term := range a.NodeSelector.NodeSelectorTerms {
+		if len(term.MatchExpressions) > 0 {
+			// MatchExpressions is label-based and ORed in with MatchFields,
+			// so this term can match a broader, non-enumerable set of nodes
+			// that MatchFields alone doesn't capture. Reporting only the
+			// MatchFields names here would under-report which nodes the
+			// allocation is actually available on.
+			return nil
+		}
+		for _, expr := range term.MatchFields {
+			if expr.Key != nodeNameField || expr.Operator != v1.NodeSelectorOpIn {
+				return nil
+			}
+			names = append(names, expr.Values...)
+		}
+	}
+	return names
+}
+
+// Slice is a version-neutral view of a ResourceSlice.
+type Slice struct {
+	Name       string
+	DriverName string
+	NodeName   string
+}
+
+// DeviceClass is a version-neutral view of a DeviceClass (v1alpha3) or
+// ResourceClass (v1alpha2).
+type DeviceClass struct {
+	Name string
+}
+
+// PodSchedulingContext is a version-neutral view of the v1alpha2
+// PodSchedulingContext object used to coordinate WaitForFirstConsumer claims
+// between the scheduler and DRA drivers. It has no v1alpha3 equivalent, so
+// converters for that version return ok=false.
+type PodSchedulingContext struct {
+	Namespace string
+	PodName   string
+
+	// SelectedNode mirrors PodSchedulingContextSpec.SelectedNode: the node
+	// the scheduler has tentatively picked and is waiting for drivers to
+	// confirm or reject.
+	SelectedNode string
+
+	// ResourceClaims mirrors PodSchedulingContextStatus.ResourceClaims: the
+	// per-claim responses drivers have posted back.
+	ResourceClaims []ResourceClaimSchedulingStatus
+}
+
+// ResourceClaimSchedulingStatus mirrors the per-claim entry in
+// PodSchedulingContextStatus.ResourceClaims.
+type ResourceClaimSchedulingStatus struct {
+	Name            string
+	UnsuitableNodes []string
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestAllocationResultNodeNames(t *testing.T) {
+	fieldTerm := func(names ...string) v1.NodeSelectorTerm {
+		return v1.NodeSelectorTerm{
+			MatchFields: []v1.NodeSelectorRequirement{
+				{Key: nodeNameField, Operator: v1.NodeSelectorOpIn, Values: names},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		allocation *AllocationResult
+		want       []string
+	}{
+		"nil allocation": {
+			allocation: nil,
+			want:       nil,
+		},
+		"nil node selector means available everywhere": {
+			allocation: &AllocationResult{},
+			want:       nil,
+		},
+		"single field term is pinned to its names": {
+			allocation: &AllocationResult{
+				NodeSelector: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{fieldTerm("node-a")}},
+			},
+			want: []string{"node-a"},
+		},
+		"multiple field terms union their names": {
+			allocation: &AllocationResult{
+				NodeSelector: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+					fieldTerm("node-a"),
+					fieldTerm("node-b", "node-c"),
+				}},
+			},
+			want: []string{"node-a", "node-b", "node-c"},
+		},
+		"unrecognized field key is not resolvable": {
+			allocation: &AllocationResult{
+				NodeSelector: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+					{MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.other", Operator: v1.NodeSelectorOpIn, Values: []string{"node-a"}}}},
+				}},
+			},
+			want: nil,
+		},
+		"a term with match expressions is not resolvable, even alongside a match fields term": {
+			allocation: &AllocationResult{
+				NodeSelector: &v1.NodeSelector{NodeSelectorTerms: []v1.NodeSelectorTerm{
+					fieldTerm("node-a"),
+					{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east"}}}},
+				}},
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.allocation.NodeNames()
+			if len(got) != len(tc.want) {
+				t.Fatalf("NodeNames() = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("NodeNames() = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+	"k8s.io/kubernetes/pkg/scheduler/util/assumecache"
+)
+
+// InformerMode selects how a DraManager gets its informers: reusing the
+// scheduler's shared informer factory (Shared, the default), or building and
+// owning a private one (Dedicated).
+//
+// cluster-autoscaler typically wants Dedicated: the main scheduler informer
+// factory is being churned by unrelated pod/node events while CA is
+// snapshotting, so a private, resync-controlled subscription gives it a more
+// predictable view of DRA state at the cost of one extra watch per resource.
+type InformerMode int
+
+const (
+	// SharedInformers reuses the informers.SharedInformerFactory passed to
+	// NewDraManager. Its lifecycle (Start/WaitForCacheSync) is the caller's
+	// responsibility, as is true of every other consumer of that factory.
+	SharedInformers InformerMode = iota
+	// DedicatedInformers builds a private informers.SharedInformerFactory
+	// per watched resource, started and synced through DraManager's own
+	// Start/WaitForCacheSync methods.
+	DedicatedInformers
+)
+
+// ListOptionsSelector optionally narrows down the objects a dedicated
+// informer watches, to keep its resync and watch traffic proportional to
+// what cluster-autoscaler actually needs to see.
+type ListOptionsSelector struct {
+	FieldSelector string
+	LabelSelector string
+}
+
+func (s ListOptionsSelector) tweak(opts *metav1.ListOptions) {
+	if s.FieldSelector != "" {
+		opts.FieldSelector = s.FieldSelector
+	}
+	if s.LabelSelector != "" {
+		opts.LabelSelector = s.LabelSelector
+	}
+}
+
+// DedicatedInformerOptions configures the private informer factories built
+// for InformerMode DedicatedInformers.
+type DedicatedInformerOptions struct {
+	// Client is used to construct the dedicated informer factories. It is
+	// typically a separate client from the one backing the scheduler's
+	// shared informers, so the dedicated watches don't compete with them
+	// for the same client's rate limiter.
+	Client clientset.Interface
+	// ResyncPeriod controls how often the dedicated informers do a full
+	// relist, independent of whatever resync period the shared scheduler
+	// informers use.
+	ResyncPeriod time.Duration
+
+	ResourceClaimSelector ListOptionsSelector
+	ResourceSliceSelector ListOptionsSelector
+	DeviceClassSelector   ListOptionsSelector
+	// PodSchedulingContextSelector narrows the PodSchedulingContext watch in
+	// API versions that still have the resource (v1alpha2 only). It is
+	// independent of ResourceClaimSelector: PodSchedulingContexts get their
+	// own factory precisely so a claim selector never leaks onto them.
+	PodSchedulingContextSelector ListOptionsSelector
+}
+
+// NewDedicatedDraManager builds a DraManager in InformerMode
+// DedicatedInformers: it owns a private informers.SharedInformerFactory per
+// watched resource (so each can carry its own field/label selector) and a
+// ResourceClaim assume cache rebuilt on top of its own dedicated claim
+// informer, rather than one shared with the rest of the scheduler.
+//
+// Callers must call Start and WaitForCacheSync on the returned DraManager
+// before using it; nothing does that automatically, mirroring how callers
+// are expected to drive informers.SharedInformerFactory themselves.
+func NewDedicatedDraManager(version dra.APIVersion, opts DedicatedInformerOptions) (*DraManager, error) {
+	converter, err := dra.ConverterFor(version)
+	if err != nil {
+		return nil, err
+	}
+
+	claimFactory := informers.NewSharedInformerFactoryWithOptions(opts.Client, opts.ResyncPeriod,
+		informers.WithTweakListOptions(opts.ResourceClaimSelector.tweak))
+	sliceFactory := informers.NewSharedInformerFactoryWithOptions(opts.Client, opts.ResyncPeriod,
+		informers.WithTweakListOptions(opts.ResourceSliceSelector.tweak))
+	classFactory := informers.NewSharedInformerFactoryWithOptions(opts.Client, opts.ResyncPeriod,
+		informers.WithTweakListOptions(opts.DeviceClassSelector.tweak))
+	pscFactory := informers.NewSharedInformerFactoryWithOptions(opts.Client, opts.ResyncPeriod,
+		informers.WithTweakListOptions(opts.PodSchedulingContextSelector.tweak))
+
+	claimVerInformers, err := newVersionedInformers(version, claimFactory)
+	if err != nil {
+		return nil, err
+	}
+	sliceVerInformers, err := newVersionedInformers(version, sliceFactory)
+	if err != nil {
+		return nil, err
+	}
+	classVerInformers, err := newVersionedInformers(version, classFactory)
+	if err != nil {
+		return nil, err
+	}
+	pscVerInformers, err := newVersionedInformers(version, pscFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	claimsCache := assumecache.NewAssumeCache(klog.Background(), claimVerInformers.claims(), "resourceclaim", "", nil)
+
+	m, err := newDraManager(version, converter, claimsCache, multiFactoryInformers{
+		claimInformers: claimVerInformers,
+		sliceInformers: sliceVerInformers,
+		classInformers: classVerInformers,
+		pscInformers:   pscVerInformers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.mode = DedicatedInformers
+	m.ownedFactories = []informers.SharedInformerFactory{claimFactory, sliceFactory, classFactory, pscFactory}
+	return m, nil
+}
+
+// multiFactoryInformers implements versionedInformers on top of four
+// independently-tweaked factories, one per resource, so each dedicated
+// informer can carry its own field/label selector.
+type multiFactoryInformers struct {
+	claimInformers versionedInformers
+	sliceInformers versionedInformers
+	classInformers versionedInformers
+	pscInformers   versionedInformers
+}
+
+func (i multiFactoryInformers) claims() cache.SharedIndexInformer {
+	return i.claimInformers.claims()
+}
+
+func (i multiFactoryInformers) slices() (cache.SharedIndexInformer, bool) {
+	return i.sliceInformers.slices()
+}
+
+func (i multiFactoryInformers) deviceClasses() cache.SharedIndexInformer {
+	return i.classInformers.deviceClasses()
+}
+
+func (i multiFactoryInformers) podSchedulingContexts() (cache.SharedIndexInformer, bool) {
+	// PodSchedulingContexts get their own factory/selector rather than
+	// riding on claimInformers: PodSchedulingContextSelector is the only
+	// selector ever applied to this resource, so a ResourceClaimSelector
+	// tuned for ResourceClaim fields can never be misapplied to it.
+	return i.pscInformers.podSchedulingContexts()
+}
+
+// Start begins running the dedicated informer factories. It is a no-op in
+// InformerMode SharedInformers, where starting the factory is the caller's
+// responsibility.
+func (s *DraManager) Start(ctx context.Context) {
+	for _, factory := range s.ownedFactories {
+		factory.Start(ctx.Done())
+	}
+}
+
+// WaitForCacheSync blocks until the dedicated informer factories' caches
+// have synced, or ctx is done. It is a no-op returning nil in InformerMode
+// SharedInformers.
+func (s *DraManager) WaitForCacheSync(ctx context.Context) error {
+	for _, factory := range s.ownedFactories {
+		for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+			if !synced {
+				return fmt.Errorf("failed to sync informer for %v", informerType)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+	"k8s.io/kubernetes/pkg/scheduler/util/assumecache"
+)
+
+func newTestAssumeCache(t *testing.T, informer cache.SharedIndexInformer) *assumecache.AssumeCache {
+	t.Helper()
+	return assumecache.NewAssumeCache(klog.Background(), informer, "resourceclaim", "", nil)
+}
+
+func TestNewDedicatedDraManagerStartsAndSyncsOwnedFactories(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	m, err := NewDedicatedDraManager(dra.APIVersionV1alpha3, DedicatedInformerOptions{
+		Client:       client,
+		ResyncPeriod: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.mode != DedicatedInformers {
+		t.Fatalf("expected mode DedicatedInformers, got %v", m.mode)
+	}
+	if len(m.ownedFactories) == 0 {
+		t.Fatalf("expected NewDedicatedDraManager to own at least one informer factory")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m.Start(ctx)
+	if err := m.WaitForCacheSync(ctx); err != nil {
+		t.Fatalf("WaitForCacheSync: %v", err)
+	}
+}
+
+func TestDraManagerStartAndWaitForCacheSyncAreNoopsInSharedMode(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+
+	claimInformer := informerFactory.Resource().V1alpha3().ResourceClaims().Informer()
+	m, err := NewDraManager(newTestAssumeCache(t, claimInformer), informerFactory, dra.APIVersionV1alpha3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.mode != SharedInformers {
+		t.Fatalf("expected mode SharedInformers, got %v", m.mode)
+	}
+	if len(m.ownedFactories) != 0 {
+		t.Fatalf("expected ownedFactories to stay empty in SharedInformers mode, got %v", m.ownedFactories)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Neither call starts informerFactory, so this must return immediately
+	// without blocking on a cache sync that will never happen.
+	m.Start(ctx)
+	if err := m.WaitForCacheSync(ctx); err != nil {
+		t.Fatalf("WaitForCacheSync: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+)
+
+// versionedInformers hides which DRA API version is actually being watched
+// behind a single set of cache.SharedIndexInformers, so the rest of
+// DraManager only has to deal with dra.Converter to interpret what comes out
+// of the stores.
+type versionedInformers interface {
+	claims() cache.SharedIndexInformer
+	slices() (cache.SharedIndexInformer, bool)
+	deviceClasses() cache.SharedIndexInformer
+	podSchedulingContexts() (cache.SharedIndexInformer, bool)
+}
+
+// newVersionedInformers builds the versionedInformers for the negotiated API
+// version on top of the given factory.
+func newVersionedInformers(version dra.APIVersion, informerFactory informers.SharedInformerFactory) (versionedInformers, error) {
+	switch version {
+	case dra.APIVersionV1alpha3:
+		return v1alpha3Informers{factory: informerFactory}, nil
+	case dra.APIVersionV1alpha2:
+		return v1alpha2Informers{factory: informerFactory}, nil
+	default:
+		return nil, fmt.Errorf("unsupported DRA API version: %s", version)
+	}
+}
+
+type v1alpha3Informers struct {
+	factory informers.SharedInformerFactory
+}
+
+func (i v1alpha3Informers) claims() cache.SharedIndexInformer {
+	return i.factory.Resource().V1alpha3().ResourceClaims().Informer()
+}
+
+func (i v1alpha3Informers) slices() (cache.SharedIndexInformer, bool) {
+	return i.factory.Resource().V1alpha3().ResourceSlices().Informer(), true
+}
+
+func (i v1alpha3Informers) deviceClasses() cache.SharedIndexInformer {
+	return i.factory.Resource().V1alpha3().DeviceClasses().Informer()
+}
+
+func (i v1alpha3Informers) podSchedulingContexts() (cache.SharedIndexInformer, bool) {
+	// PodSchedulingContext was removed in v1alpha3.
+	return nil, false
+}
+
+type v1alpha2Informers struct {
+	factory informers.SharedInformerFactory
+}
+
+func (i v1alpha2Informers) claims() cache.SharedIndexInformer {
+	return i.factory.Resource().V1alpha2().ResourceClaims().Informer()
+}
+
+func (i v1alpha2Informers) slices() (cache.SharedIndexInformer, bool) {
+	// v1alpha2 had no ResourceSlice equivalent.
+	return nil, false
+}
+
+func (i v1alpha2Informers) deviceClasses() cache.SharedIndexInformer {
+	return i.factory.Resource().V1alpha2().ResourceClasses().Informer()
+}
+
+func (i v1alpha2Informers) podSchedulingContexts() (cache.SharedIndexInformer, bool) {
+	return i.factory.Resource().V1alpha2().PodSchedulingContexts().Informer(), true
+}
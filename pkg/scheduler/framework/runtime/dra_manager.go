@@ -2,30 +2,180 @@ package runtime
 
 import (
 	"fmt"
-	resourceapi "k8s.io/api/resource/v1alpha3"
-	"k8s.io/apimachinery/pkg/labels"
+	"sync"
+	"time"
+
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
-	resourcelisters "k8s.io/client-go/listers/resource/v1alpha3"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra/metrics"
 	"k8s.io/kubernetes/pkg/scheduler/util/assumecache"
-	"sync"
+)
+
+// Index names registered on the ResourceClaim assume cache and the
+// ResourceSlice informer indexer. See claimTracker.ListAllocatedOnNode,
+// claimTracker.ListByDeviceClass and resourceSliceLister.ListByDriver.
+const (
+	claimNodeNameIndex    = "dra-claim-node-name"
+	claimDeviceClassIndex = "dra-claim-device-class"
+	sliceDriverNameIndex  = "dra-slice-driver-name"
 )
 
 var _ framework.SharedDraManager = &DraManager{}
 
+// DraManager tracks DRA state (claims, slices, device classes and, on
+// clusters still running the v1alpha2 API, PodSchedulingContexts) for the
+// scheduler framework and for cluster-autoscaler's snapshotting.
+//
+// It is built against a single negotiated dra.APIVersion (see
+// NegotiateAPIVersion) and converts every object it sees through the
+// matching dra.Converter, so everything downstream of construction works
+// with the neutral dra.Claim/dra.Slice/dra.DeviceClass types and does not
+// need to know which API version the cluster actually runs.
 type DraManager struct {
-	resourceClaimTracker *claimTracker
-	resourceSliceLister  *resourceSliceLister
-	deviceClassLister    *deviceClassLister
+	version   dra.APIVersion
+	converter dra.Converter
+	// mode records how this DraManager got its informers, purely for
+	// introspection; behavior differences live in ownedFactories being
+	// empty (Shared) or not (Dedicated).
+	mode InformerMode
+	// ownedFactories are started and synced by Start/WaitForCacheSync. It is
+	// empty in InformerMode SharedInformers, where the caller owns the
+	// informers.SharedInformerFactory passed into NewDraManager.
+	ownedFactories []informers.SharedInformerFactory
+
+	resourceClaimTracker        *claimTracker
+	resourceSliceLister         *resourceSliceLister
+	deviceClassLister           *deviceClassLister
+	podSchedulingContextTracker *podSchedulingContextTracker
+}
+
+// NewDraManager builds a DraManager in InformerMode SharedInformers against
+// the given negotiated API version, reusing informerFactory. claimsCache
+// must already be wired up to an informer for ResourceClaims of that same
+// version and factory (see assumecache.NewAssumeCache, as used by
+// NewDedicatedDraManager), and must not already carry the claim indexes
+// that this constructor registers on it.
+//
+// Starting and syncing informerFactory remains the caller's responsibility,
+// as for any other consumer of a shared factory. Use NewDedicatedDraManager
+// instead if DraManager should own and drive its informers itself.
+func NewDraManager(claimsCache *assumecache.AssumeCache, informerFactory informers.SharedInformerFactory, version dra.APIVersion) (*DraManager, error) {
+	converter, err := dra.ConverterFor(version)
+	if err != nil {
+		return nil, err
+	}
+	verInformers, err := newVersionedInformers(version, informerFactory)
+	if err != nil {
+		return nil, err
+	}
+	return newDraManager(version, converter, claimsCache, verInformers)
+}
+
+// NewDraManagerWithMetrics is NewDraManager with the dra/metrics Prometheus
+// metrics registered with the legacy registry. It is split out from
+// NewDraManager, rather than taking an "enable metrics" bool, so that tests
+// constructing many DraManagers through the plain constructor never risk
+// double-registering the (process-global) metrics -- registration here is
+// idempotent, but callers that don't want it shouldn't have to think about
+// that at all.
+func NewDraManagerWithMetrics(claimsCache *assumecache.AssumeCache, informerFactory informers.SharedInformerFactory, version dra.APIVersion) (*DraManager, error) {
+	metrics.Register()
+	return NewDraManager(claimsCache, informerFactory, version)
+}
+
+// newDraManager holds the construction logic shared between NewDraManager
+// (InformerMode SharedInformers) and NewDedicatedDraManager (InformerMode
+// DedicatedInformers): registering indexes and building the listers/tracker
+// on top of whatever versionedInformers it is handed. The caller is
+// responsible for setting mode and ownedFactories on the result.
+func newDraManager(version dra.APIVersion, converter dra.Converter, claimsCache *assumecache.AssumeCache, verInformers versionedInformers) (*DraManager, error) {
+	if err := claimsCache.AddIndexers(cache.Indexers{
+		claimNodeNameIndex:    claimNodeNameIndexFunc(converter),
+		claimDeviceClassIndex: claimDeviceClassIndexFunc(converter),
+	}); err != nil {
+		return nil, fmt.Errorf("adding indexers to claims assume cache: %w", err)
+	}
+
+	m := &DraManager{
+		version:   version,
+		converter: converter,
+		resourceClaimTracker: &claimTracker{
+			cache:               claimsCache,
+			converter:           converter,
+			inFlightAllocations: &sync.Map{},
+			foreignAllocations:  &sync.Map{},
+		},
+		deviceClassLister: &deviceClassLister{
+			indexer:   verInformers.deviceClasses().GetIndexer(),
+			converter: converter,
+		},
+	}
+
+	if sliceInformer, ok := verInformers.slices(); ok {
+		if err := sliceInformer.AddIndexers(cache.Indexers{
+			sliceDriverNameIndex: sliceDriverNameIndexFunc(converter),
+		}); err != nil {
+			return nil, fmt.Errorf("adding indexers to resource slice informer: %w", err)
+		}
+		m.resourceSliceLister = &resourceSliceLister{
+			indexer:   sliceInformer.GetIndexer(),
+			converter: converter,
+		}
+	}
+	if pscInformer, ok := verInformers.podSchedulingContexts(); ok {
+		m.podSchedulingContextTracker = &podSchedulingContextTracker{
+			indexer:   pscInformer.GetIndexer(),
+			converter: converter,
+		}
+	}
+
+	if _, err := verInformers.claims().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { onClaimEvent(m.resourceClaimTracker, converter, obj) },
+		UpdateFunc: func(_, newObj interface{}) { onClaimEvent(m.resourceClaimTracker, converter, newObj) },
+		DeleteFunc: func(obj interface{}) { onClaimDelete(m.resourceClaimTracker, converter, obj) },
+	}); err != nil {
+		return nil, fmt.Errorf("adding claim event handler: %w", err)
+	}
+
+	return m, nil
+}
+
+// onClaimEvent is the ResourceClaim informer add/update handler that keeps
+// claimTracker.foreignAllocations in sync: whenever a claim shows up with an
+// allocation we never signaled ourselves via SignalClaimPendingAllocation, it
+// must have been allocated by something else (a driver, another scheduler,
+// or a manual edit).
+func onClaimEvent(tracker *claimTracker, converter dra.Converter, obj interface{}) {
+	claim, ok := converter.ToClaim(obj)
+	if !ok {
+		return
+	}
+	if claim.Allocation == nil {
+		tracker.ForgetForeignAllocation(claim.UID)
+		return
+	}
+	if tracker.ClaimHasPendingAllocation(claim.UID) {
+		// We signaled this allocation ourselves. tagProvenance already
+		// prefers inFlightAllocations over foreignAllocations, but there's
+		// no reason to also carry it in the latter.
+		return
+	}
+	tracker.MarkClaimForeignAllocated(claim.UID, claim)
 }
 
-func NewDraManager(claimsCache *assumecache.AssumeCache, informerFactory informers.SharedInformerFactory) *DraManager {
-	return &DraManager{
-		resourceClaimTracker: &claimTracker{cache: claimsCache, inFlightAllocations: &sync.Map{}},
-		resourceSliceLister:  &resourceSliceLister{sliceLister: informerFactory.Resource().V1alpha3().ResourceSlices().Lister()},
-		deviceClassLister:    &deviceClassLister{classLister: informerFactory.Resource().V1alpha3().DeviceClasses().Lister()},
+// onClaimDelete is the ResourceClaim informer delete handler.
+func onClaimDelete(tracker *claimTracker, converter dra.Converter, obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	claim, ok := converter.ToClaim(obj)
+	if !ok {
+		return
 	}
+	tracker.ForgetForeignAllocation(claim.UID)
 }
 
 func (s *DraManager) ResourceClaims() framework.ResourceClaimTracker {
@@ -40,28 +190,162 @@ func (s *DraManager) DeviceClasses() framework.DeviceClassLister {
 	return s.deviceClassLister
 }
 
+// PodSchedulingContexts returns the PodSchedulingContext tracker, or nil if
+// the negotiated API version (v1alpha3 and newer) has no such object. Callers
+// that need WaitForFirstConsumer coordination must check for nil before
+// using the result.
+func (s *DraManager) PodSchedulingContexts() framework.PodSchedulingContextLister {
+	if s.podSchedulingContextTracker == nil {
+		return nil
+	}
+	return s.podSchedulingContextTracker
+}
+
+// sliceDriverNameIndexFunc indexes ResourceSlices by the driver that
+// published them, so ListByDriver doesn't have to walk every slice in the
+// cluster.
+func sliceDriverNameIndexFunc(converter dra.Converter) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		slice, ok := converter.ToSlice(obj)
+		if !ok || slice.DriverName == "" {
+			return nil, nil
+		}
+		return []string{slice.DriverName}, nil
+	}
+}
+
 var _ framework.ResourceSliceLister = &resourceSliceLister{}
 
 type resourceSliceLister struct {
-	sliceLister resourcelisters.ResourceSliceLister
+	indexer   cache.Indexer
+	converter dra.Converter
 }
 
-func (l *resourceSliceLister) List() ([]*resourceapi.ResourceSlice, error) {
-	return l.sliceLister.List(labels.Everything())
+func (l *resourceSliceLister) List() ([]*dra.Slice, error) {
+	if l == nil {
+		return nil, nil
+	}
+	objs := l.indexer.List()
+	result := make([]*dra.Slice, 0, len(objs))
+	for _, obj := range objs {
+		if slice, ok := l.converter.ToSlice(obj); ok {
+			result = append(result, slice)
+		}
+	}
+	return result, nil
+}
+
+// ListByDriver returns only the slices published by the given driver, using
+// the sliceDriverNameIndex index rather than scanning every slice.
+func (l *resourceSliceLister) ListByDriver(driverName string) ([]*dra.Slice, error) {
+	if l == nil {
+		return nil, nil
+	}
+	objs, err := l.indexer.ByIndex(sliceDriverNameIndex, driverName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dra.Slice, 0, len(objs))
+	for _, obj := range objs {
+		if slice, ok := l.converter.ToSlice(obj); ok {
+			result = append(result, slice)
+		}
+	}
+	return result, nil
 }
 
 var _ framework.DeviceClassLister = &deviceClassLister{}
 
 type deviceClassLister struct {
-	classLister resourcelisters.DeviceClassLister
+	indexer   cache.Indexer
+	converter dra.Converter
+}
+
+func (l *deviceClassLister) Get(className string) (*dra.DeviceClass, error) {
+	obj, exists, err := l.indexer.GetByKey(className)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("deviceclass %q not found", className)
+	}
+	class, ok := l.converter.ToDeviceClass(obj)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T for device class %s", obj, className)
+	}
+	return class, nil
 }
 
-func (l *deviceClassLister) Get(className string) (*resourceapi.DeviceClass, error) {
-	return l.classLister.Get(className)
+func (l *deviceClassLister) List() ([]*dra.DeviceClass, error) {
+	objs := l.indexer.List()
+	result := make([]*dra.DeviceClass, 0, len(objs))
+	for _, obj := range objs {
+		if class, ok := l.converter.ToDeviceClass(obj); ok {
+			result = append(result, class)
+		}
+	}
+	return result, nil
 }
 
-func (l *deviceClassLister) List() ([]*resourceapi.DeviceClass, error) {
-	return l.classLister.List(labels.Everything())
+// podSchedulingContextTracker lists PodSchedulingContext objects, converted
+// to the neutral dra.PodSchedulingContext type. It only exists when the
+// cluster runs the v1alpha2 DRA API.
+type podSchedulingContextTracker struct {
+	indexer   cache.Indexer
+	converter dra.Converter
+}
+
+func (t *podSchedulingContextTracker) Get(namespace, podName string) (*dra.PodSchedulingContext, error) {
+	obj, exists, err := t.indexer.GetByKey(namespace + "/" + podName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("podschedulingcontext %s/%s not found", namespace, podName)
+	}
+	pcs, ok := t.converter.ToPodSchedulingContext(obj)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T for podschedulingcontext %s/%s", obj, namespace, podName)
+	}
+	return pcs, nil
+}
+
+func (t *podSchedulingContextTracker) List() ([]*dra.PodSchedulingContext, error) {
+	objs := t.indexer.List()
+	result := make([]*dra.PodSchedulingContext, 0, len(objs))
+	for _, obj := range objs {
+		if pcs, ok := t.converter.ToPodSchedulingContext(obj); ok {
+			result = append(result, pcs)
+		}
+	}
+	return result, nil
+}
+
+// claimNodeNameIndexFunc indexes ResourceClaims by the node name(s) their
+// allocation is pinned to, so ListAllocatedOnNode doesn't have to walk every
+// claim in the cluster. Claims that are unallocated, or whose allocation
+// isn't pinned to a fixed set of nodes, are not indexed.
+func claimNodeNameIndexFunc(converter dra.Converter) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		claim, ok := converter.ToClaim(obj)
+		if !ok {
+			return nil, nil
+		}
+		return claim.Allocation.NodeNames(), nil
+	}
+}
+
+// claimDeviceClassIndexFunc indexes ResourceClaims by the device class(es)
+// their spec requests, so ListByDeviceClass doesn't have to walk every claim
+// in the cluster.
+func claimDeviceClassIndexFunc(converter dra.Converter) cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		claim, ok := converter.ToClaim(obj)
+		if !ok {
+			return nil, nil
+		}
+		return claim.RequestedDeviceClasses, nil
+	}
 }
 
 var _ framework.ResourceClaimTracker = &claimTracker{}
@@ -90,6 +374,10 @@ type claimTracker struct {
 	// something like it (see https://github.com/kubernetes/kubernetes/pull/112202)
 	// might have to be managed by the cluster autoscaler.
 	cache *assumecache.AssumeCache
+	// converter turns whatever version-specific object the cache stores
+	// (negotiated once, at DraManager construction) into the neutral
+	// dra.Claim type.
+	converter dra.Converter
 	// inFlightAllocations is map from claim UUIDs to claim objects for those claims
 	// for which allocation was triggered during a scheduling cycle and the
 	// corresponding claim status update call in PreBind has not been done
@@ -120,6 +408,22 @@ type claimTracker struct {
 	// hitting the "multiple goroutines read, write, and overwrite entries
 	// for disjoint sets of keys" case that sync.Map is optimized for.
 	inFlightAllocations *sync.Map
+	// foreignAllocations is a map from claim UIDs to claim objects for
+	// claims whose Status.Allocation was observed to be set by something
+	// other than our own scheduling cycle. It is populated by the informer
+	// event handler (see MarkClaimForeignAllocated) whenever an allocation
+	// shows up on a claim we never signaled via SignalClaimPendingAllocation,
+	// and evicted when the claim is deleted or its allocation disappears.
+	foreignAllocations *sync.Map
+}
+
+// pendingAllocation is what inFlightAllocations actually stores: the claim
+// itself plus when it was signaled, so RemoveClaimPendingAllocation can
+// report how long the PreBind stall lasted via
+// metrics.ClaimPendingAllocationDuration.
+type pendingAllocation struct {
+	claim      *dra.Claim
+	signaledAt time.Time
 }
 
 func (c *claimTracker) ClaimHasPendingAllocation(claimUid types.UID) bool {
@@ -127,74 +431,241 @@ func (c *claimTracker) ClaimHasPendingAllocation(claimUid types.UID) bool {
 	return found
 }
 
-func (c *claimTracker) SignalClaimPendingAllocation(claimUid types.UID, allocatedClaim *resourceapi.ResourceClaim) {
-	c.inFlightAllocations.Store(claimUid, allocatedClaim)
+func (c *claimTracker) SignalClaimPendingAllocation(claimUid types.UID, allocatedClaim *dra.Claim) {
+	_, replaced := c.inFlightAllocations.Swap(claimUid, &pendingAllocation{claim: allocatedClaim, signaledAt: time.Now()})
+	metrics.ClaimPendingAllocationSignaledTotal.Inc()
+	if !replaced {
+		metrics.ClaimPendingAllocations.Inc()
+	}
 }
 
 func (c *claimTracker) RemoveClaimPendingAllocation(claimUid types.UID) (found bool) {
-	_, found = c.inFlightAllocations.LoadAndDelete(claimUid)
-	return found
+	v, found := c.inFlightAllocations.LoadAndDelete(claimUid)
+	if !found {
+		return false
+	}
+	metrics.ClaimPendingAllocationRemovedTotal.Inc()
+	metrics.ClaimPendingAllocations.Dec()
+	metrics.ClaimPendingAllocationDuration.Observe(time.Since(v.(*pendingAllocation).signaledAt).Seconds())
+	return true
 }
 
-func (c *claimTracker) Get(namespace, claimName string) (*resourceapi.ResourceClaim, error) {
+func (c *claimTracker) Get(namespace, claimName string) (*dra.Claim, error) {
 	obj, err := c.cache.Get(namespace + "/" + claimName)
 	if err != nil {
+		metrics.ClaimCacheMissTotal.WithLabelValues("Get").Inc()
 		return nil, err
 	}
-	claim, ok := obj.(*resourceapi.ResourceClaim)
+	claim, ok := c.converter.ToClaim(obj)
 	if !ok {
 		return nil, fmt.Errorf("unexpected object type %T for assumed object %s/%s", obj, namespace, claimName)
 	}
 	return claim, nil
 }
 
-func (c *claimTracker) GetOriginal(namespace, claimName string) (*resourceapi.ResourceClaim, error) {
+func (c *claimTracker) GetOriginal(namespace, claimName string) (*dra.Claim, error) {
 	obj, err := c.cache.GetAPIObj(namespace + "/" + claimName)
 	if err != nil {
+		metrics.ClaimCacheMissTotal.WithLabelValues("GetOriginal").Inc()
 		return nil, err
 	}
-	claim, ok := obj.(*resourceapi.ResourceClaim)
+	claim, ok := c.converter.ToClaim(obj)
 	if !ok {
 		return nil, fmt.Errorf("unexpected object type %T for assumed object %s/%s", obj, namespace, claimName)
 	}
 	return claim, nil
 }
 
-func (c *claimTracker) List() ([]*resourceapi.ResourceClaim, error) {
-	var result []*resourceapi.ResourceClaim
+func (c *claimTracker) List() ([]*dra.Claim, error) {
+	var result []*dra.Claim
 	// Probably not worth adding an index for?
 	objs := c.cache.List(nil)
 	for _, obj := range objs {
-		claim, ok := obj.(*resourceapi.ResourceClaim)
-		if ok {
+		if claim, ok := c.converter.ToClaim(obj); ok {
 			result = append(result, claim)
 		}
 	}
 	return result, nil
 }
 
-func (c *claimTracker) ListAllAllocated() ([]*resourceapi.ResourceClaim, error) {
+// MarkClaimForeignAllocated records that the given claim's allocation was
+// observed to have been set by something other than our own scheduling
+// cycle. It is driven from the ResourceClaim informer event handler: on an
+// add/update event where Status.Allocation is set but
+// ClaimHasPendingAllocation(claim.UID) is false, the handler calls this with
+// the freshly converted claim before it ever reaches the assume cache as
+// "ours".
+func (c *claimTracker) MarkClaimForeignAllocated(uid types.UID, claim *dra.Claim) {
+	c.foreignAllocations.Store(uid, claim)
+}
+
+// ForgetForeignAllocation evicts a claim from the foreign-allocation set,
+// called from the informer event handler on delete, or on an update that
+// clears Status.Allocation.
+func (c *claimTracker) ForgetForeignAllocation(uid types.UID) {
+	c.foreignAllocations.Delete(uid)
+}
+
+// ListForeignAllocated returns the claims currently known to have been
+// allocated by something other than our own scheduling cycle.
+func (c *claimTracker) ListForeignAllocated() ([]*dra.Claim, error) {
+	var result []*dra.Claim
+	c.foreignAllocations.Range(func(_, value interface{}) bool {
+		result = append(result, value.(*dra.Claim))
+		return true
+	})
+	return result, nil
+}
+
+// ListAllAllocated returns every allocated claim in the cluster. Unlike
+// ListAllocatedOnNode/ListByDeviceClass, there is no narrower index to
+// iterate here: claimNodeNameIndex is keyed by node name, and the set of
+// node names that currently hold an allocation is exactly what this call is
+// trying to discover, so there is nothing to union over without first doing
+// the full scan it would be trying to avoid. It stays on the unindexed
+// c.List().
+func (c *claimTracker) ListAllAllocated() ([]*dra.Claim, error) {
 	claims, err := c.List()
 	if err != nil {
 		return nil, err
 	}
-	allocated := make([]*resourceapi.ResourceClaim, 0, len(claims))
+	return c.tagProvenance(claims), nil
+}
+
+// tagForeign returns a copy of claim with ForeignAllocation set. It exists so
+// every path that surfaces a foreignAllocations entry -- tagProvenance's own
+// merge below, and ListAllocatedOnNode's separate fold-in of claims the node
+// index can't see yet -- tags it the same way, rather than each copying the
+// "clone and flip the bit" logic (and risking forgetting the flip) on its own.
+func tagForeign(claim *dra.Claim) *dra.Claim {
+	foreign := *claim
+	foreign.ForeignAllocation = true
+	return &foreign
+}
+
+// tagProvenance filters claims down to the allocated ones and, for each,
+// resolves which of our three sources of truth about its allocation wins:
+//  1. inFlightAllocations, if our own PreBind signaled an allocation that
+//     has not yet been persisted/observed through the informer.
+//  2. foreignAllocations, if the allocation was observed to have been set by
+//     something other than us; the claim is tagged ForeignAllocation so
+//     cluster-autoscaler can treat it as a non-relocatable reservation.
+//  3. The claim as read from the cache, otherwise.
+//
+// It is split out from ListAllAllocated so the provenance-resolution logic
+// can be unit tested without needing a real assume cache.
+func (c *claimTracker) tagProvenance(claims []*dra.Claim) []*dra.Claim {
+	allocated := make([]*dra.Claim, 0, len(claims))
 	for _, origClaim := range claims {
 		claim := origClaim
-		if obj, ok := c.inFlightAllocations.Load(claim.UID); ok {
-			claim = obj.(*resourceapi.ResourceClaim)
+		switch {
+		case c.ClaimHasPendingAllocation(claim.UID):
+			if obj, ok := c.inFlightAllocations.Load(claim.UID); ok {
+				claim = obj.(*pendingAllocation).claim
+			}
+		case claim.Allocation != nil:
+			if obj, ok := c.foreignAllocations.Load(claim.UID); ok {
+				claim = tagForeign(obj.(*dra.Claim))
+			}
 		}
-		if claim.Status.Allocation != nil {
+		if claim.Allocation != nil {
 			allocated = append(allocated, claim)
 		}
 	}
-	return allocated, nil
+	return allocated
 }
 
-func (c *claimTracker) AssumeClaimAfterApiCall(claim *resourceapi.ResourceClaim) error {
-	return c.cache.Assume(claim)
+// ListAllocatedOnNode returns the allocated claims whose allocation is
+// pinned to nodeName, using the claimNodeNameIndex index instead of scanning
+// every claim in the cluster.
+func (c *claimTracker) ListAllocatedOnNode(nodeName string) ([]*dra.Claim, error) {
+	objs, err := c.cache.ByIndex(claimNodeNameIndex, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make([]*dra.Claim, 0, len(objs))
+	for _, obj := range objs {
+		if claim, ok := c.converter.ToClaim(obj); ok {
+			indexed = append(indexed, claim)
+		}
+	}
+	return c.foldInPendingOnNode(c.tagProvenance(indexed), nodeName), nil
+}
+
+// foldInPendingOnNode merges claims from inFlightAllocations and
+// foreignAllocations into result that are pinned to nodeName but missing from
+// it because their persisted object doesn't carry the allocation yet. It is
+// split out from ListAllocatedOnNode, like tagProvenance is from
+// ListAllAllocated, so this merge can be unit tested without needing a real
+// assume cache.
+//
+// inFlightAllocations and foreignAllocations aren't indexed: per the comment
+// on inFlightAllocations, sharing of a claim between pods (and hence a
+// backlog of not-yet-persisted allocations) is expected to be rare, so a
+// linear scan over them stays cheap in practice.
+func (c *claimTracker) foldInPendingOnNode(result []*dra.Claim, nodeName string) []*dra.Claim {
+	seen := make(map[types.UID]bool, len(result))
+	for _, claim := range result {
+		seen[claim.UID] = true
+	}
+	foldInPending := func(m *sync.Map, extract func(interface{}) *dra.Claim) {
+		m.Range(func(_, v interface{}) bool {
+			claim := extract(v)
+			if seen[claim.UID] || claim.Allocation == nil {
+				return true
+			}
+			for _, n := range claim.Allocation.NodeNames() {
+				if n == nodeName {
+					result = append(result, claim)
+					seen[claim.UID] = true
+					break
+				}
+			}
+			return true
+		})
+	}
+	foldInPending(c.inFlightAllocations, func(v interface{}) *dra.Claim { return v.(*pendingAllocation).claim })
+	foldInPending(c.foreignAllocations, func(v interface{}) *dra.Claim { return tagForeign(v.(*dra.Claim)) })
+
+	return result
+}
+
+// ListByDeviceClass returns the claims whose spec requests deviceClassName,
+// using the claimDeviceClassIndex index instead of scanning every claim in
+// the cluster. Unlike ListAllocatedOnNode, no merge with inFlightAllocations
+// or foreignAllocations is needed: the requested device class comes from the
+// claim's spec, which doesn't change when an allocation is signaled, so a
+// claim is indexed as soon as it is created.
+func (c *claimTracker) ListByDeviceClass(deviceClassName string) ([]*dra.Claim, error) {
+	objs, err := c.cache.ByIndex(claimDeviceClassIndex, deviceClassName)
+	if err != nil {
+		return nil, err
+	}
+	claims := make([]*dra.Claim, 0, len(objs))
+	for _, obj := range objs {
+		if claim, ok := c.converter.ToClaim(obj); ok {
+			claims = append(claims, claim)
+		}
+	}
+	return claims, nil
+}
+
+// AssumeClaimAfterApiCall takes the version-specific claim object as
+// returned by the API call that updated it (its exact type depends on the
+// negotiated DRA API version) and assumes it into the cache. It is kept
+// version-specific, rather than accepting a *dra.Claim, because the object
+// comes straight from the API response and converting it would throw away
+// information the assume cache itself needs (e.g. for its "newer than"
+// comparison).
+func (c *claimTracker) AssumeClaimAfterApiCall(claim interface{}) error {
+	if err := c.cache.Assume(claim); err != nil {
+		return err
+	}
+	metrics.ClaimAssumeTotal.Inc()
+	return nil
 }
 
 func (c *claimTracker) AssumedClaimRestore(namespace, claimName string) {
 	c.cache.Restore(namespace + "/" + claimName)
+	metrics.ClaimAssumeRestoreTotal.Inc()
 }
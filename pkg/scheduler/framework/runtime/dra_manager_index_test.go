@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+)
+
+// These tests exercise the registered cache.IndexFuncs against a real
+// cache.Indexer, rather than the claimTracker methods that query them: the
+// ResourceClaim side of that (ListAllocatedOnNode, ListByDeviceClass) reads
+// through an *assumecache.AssumeCache, which these tests do not build.
+// Testing the IndexFuncs
+// directly against cache.NewIndexer still proves the indexing itself is
+// correct; tagProvenance (the provenance-resolution logic layered on top in
+// ListAllocatedOnNode) has its own direct coverage in dra_manager_test.go.
+
+func newTestSliceIndexer(t *testing.T, converter dra.Converter, slices ...*resourceapi.ResourceSlice) cache.Indexer {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		sliceDriverNameIndex: sliceDriverNameIndexFunc(converter),
+	})
+	for _, slice := range slices {
+		if err := indexer.Add(slice); err != nil {
+			t.Fatalf("adding slice to indexer: %v", err)
+		}
+	}
+	return indexer
+}
+
+func TestResourceSliceListerListByDriver(t *testing.T) {
+	converter, err := dra.ConverterFor(dra.APIVersionV1alpha3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sliceA1 := &resourceapi.ResourceSlice{ObjectMeta: metav1.ObjectMeta{Name: "a1"}, Spec: resourceapi.ResourceSliceSpec{Driver: "driver-a"}}
+	sliceA2 := &resourceapi.ResourceSlice{ObjectMeta: metav1.ObjectMeta{Name: "a2"}, Spec: resourceapi.ResourceSliceSpec{Driver: "driver-a"}}
+	sliceB := &resourceapi.ResourceSlice{ObjectMeta: metav1.ObjectMeta{Name: "b"}, Spec: resourceapi.ResourceSliceSpec{Driver: "driver-b"}}
+
+	l := &resourceSliceLister{
+		indexer:   newTestSliceIndexer(t, converter, sliceA1, sliceA2, sliceB),
+		converter: converter,
+	}
+
+	got, err := l.ListByDriver("driver-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := make([]string, 0, len(got))
+	for _, s := range got {
+		names = append(names, s.Name)
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "a1" || names[1] != "a2" {
+		t.Fatalf("ListByDriver(driver-a) = %v, want [a1 a2]", names)
+	}
+
+	got, err = l.ListByDriver("driver-c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("ListByDriver(driver-c) = %v, want none", got)
+	}
+}
+
+func TestClaimIndexFuncsAgainstRealIndexer(t *testing.T) {
+	converter, err := dra.ConverterFor(dra.APIVersionV1alpha3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pinned := &resourceapi.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pinned"},
+		Spec: resourceapi.ResourceClaimSpec{
+			Devices: resourceapi.DeviceClaim{Requests: []resourceapi.DeviceRequest{{DeviceClassName: "gpu"}}},
+		},
+		Status: resourceapi.ResourceClaimStatus{
+			Allocation: &resourceapi.AllocationResult{
+				NodeSelector: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{{
+						MatchFields: []v1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-a"}},
+						},
+					}},
+				},
+			},
+		},
+	}
+	unpinned := &resourceapi.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "unpinned"},
+		Spec: resourceapi.ResourceClaimSpec{
+			Devices: resourceapi.DeviceClaim{Requests: []resourceapi.DeviceRequest{{DeviceClassName: "gpu"}}},
+		},
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		claimNodeNameIndex:    claimNodeNameIndexFunc(converter),
+		claimDeviceClassIndex: claimDeviceClassIndexFunc(converter),
+	})
+	for _, claim := range []*resourceapi.ResourceClaim{pinned, unpinned} {
+		if err := indexer.Add(claim); err != nil {
+			t.Fatalf("adding claim to indexer: %v", err)
+		}
+	}
+
+	byNode, err := indexer.ByIndex(claimNodeNameIndex, "node-a")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(byNode) != 1 || byNode[0].(*resourceapi.ResourceClaim).Name != "pinned" {
+		t.Fatalf("ByIndex(claimNodeNameIndex, node-a) = %v, want just [pinned]", byNode)
+	}
+
+	byClass, err := indexer.ByIndex(claimDeviceClassIndex, "gpu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(byClass) != 2 {
+		t.Fatalf("ByIndex(claimDeviceClassIndex, gpu) = %v, want both claims", byClass)
+	}
+}
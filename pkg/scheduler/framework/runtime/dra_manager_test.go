@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"sync"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra/metrics"
+)
+
+func newTestClaimTracker() *claimTracker {
+	return &claimTracker{
+		inFlightAllocations: &sync.Map{},
+		foreignAllocations:  &sync.Map{},
+	}
+}
+
+func TestClaimTrackerTagProvenance(t *testing.T) {
+	const uid = types.UID("claim-uid")
+	base := &dra.Claim{UID: uid, Namespace: "ns", Name: "claim"}
+
+	ownAllocation := &dra.Claim{UID: uid, Namespace: "ns", Name: "claim", Allocation: &dra.AllocationResult{Devices: []string{"driver/pool/own"}}}
+	foreignAllocation := &dra.Claim{UID: uid, Namespace: "ns", Name: "claim", Allocation: &dra.AllocationResult{Devices: []string{"driver/pool/foreign"}}}
+
+	t.Run("not allocated", func(t *testing.T) {
+		c := newTestClaimTracker()
+		got := c.tagProvenance([]*dra.Claim{base})
+		if len(got) != 0 {
+			t.Fatalf("expected no allocated claims, got %v", got)
+		}
+	})
+
+	t.Run("our own pending allocation wins over a stale cache read", func(t *testing.T) {
+		c := newTestClaimTracker()
+		c.SignalClaimPendingAllocation(uid, ownAllocation)
+
+		got := c.tagProvenance([]*dra.Claim{base})
+		if len(got) != 1 {
+			t.Fatalf("expected exactly one allocated claim, got %v", got)
+		}
+		if got[0].ForeignAllocation {
+			t.Errorf("claim signaled via our own PreBind must not be tagged foreign")
+		}
+		if got[0] != ownAllocation {
+			t.Errorf("expected the in-flight allocation to be returned verbatim")
+		}
+	})
+
+	t.Run("foreign allocation observed on a claim we never signaled is tagged", func(t *testing.T) {
+		c := newTestClaimTracker()
+		c.MarkClaimForeignAllocated(uid, foreignAllocation)
+
+		got := c.tagProvenance([]*dra.Claim{foreignAllocation})
+		if len(got) != 1 {
+			t.Fatalf("expected exactly one allocated claim, got %v", got)
+		}
+		if !got[0].ForeignAllocation {
+			t.Errorf("claim allocated by a third party must be tagged foreign")
+		}
+	})
+
+	t.Run("our own PreBind and a foreign controller race on the same claim", func(t *testing.T) {
+		// Regression test for the race where a device driver (or another
+		// scheduler) allocates a claim in the same window where our own
+		// PreBind is also trying to allocate it. Whatever the order of
+		// SignalClaimPendingAllocation and MarkClaimForeignAllocated, the
+		// outcome must be deterministic and ClaimHasPendingAllocation must be
+		// the tie-breaker: if we signaled it as ours, it is never reported
+		// as foreign, even if a foreign observation also landed.
+		c := newTestClaimTracker()
+		c.SignalClaimPendingAllocation(uid, ownAllocation)
+		c.MarkClaimForeignAllocated(uid, foreignAllocation)
+
+		got := c.tagProvenance([]*dra.Claim{base})
+		if len(got) != 1 {
+			t.Fatalf("expected exactly one allocated claim, got %v", got)
+		}
+		if got[0].ForeignAllocation {
+			t.Errorf("a claim we signaled ourselves must win the race and never be tagged foreign")
+		}
+
+		c.RemoveClaimPendingAllocation(uid)
+		got = c.tagProvenance([]*dra.Claim{foreignAllocation})
+		if len(got) != 1 || !got[0].ForeignAllocation {
+			t.Errorf("once our own signal is removed, the foreign allocation must take over")
+		}
+	})
+
+	t.Run("ForgetForeignAllocation evicts the entry", func(t *testing.T) {
+		c := newTestClaimTracker()
+		c.MarkClaimForeignAllocated(uid, foreignAllocation)
+		c.ForgetForeignAllocation(uid)
+
+		got := c.tagProvenance([]*dra.Claim{foreignAllocation})
+		if len(got) != 1 || got[0].ForeignAllocation {
+			t.Errorf("expected foreign tag to be gone after ForgetForeignAllocation")
+		}
+	})
+}
+
+func TestListForeignAllocated(t *testing.T) {
+	c := newTestClaimTracker()
+	a := &dra.Claim{UID: types.UID("a"), Namespace: "ns", Name: "a"}
+	b := &dra.Claim{UID: types.UID("b"), Namespace: "ns", Name: "b"}
+	c.MarkClaimForeignAllocated(a.UID, a)
+	c.MarkClaimForeignAllocated(b.UID, b)
+
+	got, err := c.ListForeignAllocated()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 foreign claims, got %d", len(got))
+	}
+}
+
+func nodePinnedAllocation(nodeName string) *dra.AllocationResult {
+	return &dra.AllocationResult{
+		NodeSelector: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchFields: []v1.NodeSelectorRequirement{
+					{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{nodeName}},
+				},
+			}},
+		},
+	}
+}
+
+func TestFoldInPendingOnNode(t *testing.T) {
+	const nodeName = "node-a"
+
+	t.Run("an in-flight allocation pinned to the node is folded in untagged", func(t *testing.T) {
+		c := newTestClaimTracker()
+		claim := &dra.Claim{UID: types.UID("in-flight"), Namespace: "ns", Name: "claim", Allocation: nodePinnedAllocation(nodeName)}
+		c.SignalClaimPendingAllocation(claim.UID, claim)
+
+		got := c.foldInPendingOnNode(nil, nodeName)
+		if len(got) != 1 {
+			t.Fatalf("expected exactly one claim folded in, got %v", got)
+		}
+		if got[0].ForeignAllocation {
+			t.Errorf("an in-flight allocation we signaled ourselves must not be tagged foreign")
+		}
+	})
+
+	t.Run("a foreign allocation pinned to the node is folded in and tagged foreign", func(t *testing.T) {
+		c := newTestClaimTracker()
+		claim := &dra.Claim{UID: types.UID("foreign"), Namespace: "ns", Name: "claim", Allocation: nodePinnedAllocation(nodeName)}
+		c.MarkClaimForeignAllocated(claim.UID, claim)
+
+		got := c.foldInPendingOnNode(nil, nodeName)
+		if len(got) != 1 {
+			t.Fatalf("expected exactly one claim folded in, got %v", got)
+		}
+		if !got[0].ForeignAllocation {
+			t.Errorf("a claim only visible through the foreignAllocations fold-in must still be tagged foreign, so cluster-autoscaler treats it as non-relocatable")
+		}
+		if got[0] == claim {
+			t.Errorf("expected the folded-in claim to be a tagged copy, not the original stored pointer")
+		}
+	})
+
+	t.Run("a foreign allocation pinned to a different node is not folded in", func(t *testing.T) {
+		c := newTestClaimTracker()
+		claim := &dra.Claim{UID: types.UID("foreign"), Namespace: "ns", Name: "claim", Allocation: nodePinnedAllocation("node-b")}
+		c.MarkClaimForeignAllocated(claim.UID, claim)
+
+		got := c.foldInPendingOnNode(nil, nodeName)
+		if len(got) != 0 {
+			t.Fatalf("expected no claims folded in, got %v", got)
+		}
+	})
+
+	t.Run("a claim already present in result is not duplicated", func(t *testing.T) {
+		c := newTestClaimTracker()
+		claim := &dra.Claim{UID: types.UID("foreign"), Namespace: "ns", Name: "claim", Allocation: nodePinnedAllocation(nodeName)}
+		c.MarkClaimForeignAllocated(claim.UID, claim)
+
+		got := c.foldInPendingOnNode([]*dra.Claim{claim}, nodeName)
+		if len(got) != 1 {
+			t.Fatalf("expected the existing entry not to be duplicated, got %v", got)
+		}
+	})
+}
+
+func TestRemoveClaimPendingAllocationReportsUnknownUIDs(t *testing.T) {
+	c := newTestClaimTracker()
+	if found := c.RemoveClaimPendingAllocation(types.UID("never-signaled")); found {
+		t.Errorf("expected RemoveClaimPendingAllocation to report false for a UID that was never signaled")
+	}
+
+	claim := &dra.Claim{UID: types.UID("claim"), Namespace: "ns", Name: "claim"}
+	c.SignalClaimPendingAllocation(claim.UID, claim)
+	if found := c.RemoveClaimPendingAllocation(claim.UID); !found {
+		t.Errorf("expected RemoveClaimPendingAllocation to report true for a UID that was signaled")
+	}
+	if found := c.RemoveClaimPendingAllocation(claim.UID); found {
+		t.Errorf("expected a second RemoveClaimPendingAllocation for the same UID to report false")
+	}
+}
+
+func TestMetricsRegisterIsIdempotent(t *testing.T) {
+	// Register is called once per NewDraManagerWithMetrics call; multiple
+	// DraManagers (e.g. one per test) must not panic on double registration.
+	metrics.Register()
+	metrics.Register()
+}
+
+// This is a regression test for the v1alpha3 claim informer event handler
+// registered in newDraManager: without it, foreignAllocations is never
+// populated outside of tests, and ListForeignAllocated/tagProvenance's
+// ForeignAllocation tag would be dead code in production.
+func TestOnClaimEventMarksForeignAllocations(t *testing.T) {
+	converter, err := dra.ConverterFor(dra.APIVersionV1alpha3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newAllocatedClaim := func(uid types.UID) *resourceapi.ResourceClaim {
+		return &resourceapi.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "claim", UID: uid},
+			Status: resourceapi.ResourceClaimStatus{
+				Allocation: &resourceapi.AllocationResult{},
+			},
+		}
+	}
+
+	t.Run("allocation we never signaled is tagged foreign", func(t *testing.T) {
+		c := newTestClaimTracker()
+		onClaimEvent(c, converter, newAllocatedClaim("uid-a"))
+
+		got, err := c.ListForeignAllocated()
+		if err != nil || len(got) != 1 {
+			t.Fatalf("expected exactly one foreign claim, got %v (err %v)", got, err)
+		}
+	})
+
+	t.Run("allocation we signaled ourselves is not tagged foreign", func(t *testing.T) {
+		c := newTestClaimTracker()
+		c.SignalClaimPendingAllocation(types.UID("uid-b"), &dra.Claim{UID: types.UID("uid-b")})
+		onClaimEvent(c, converter, newAllocatedClaim("uid-b"))
+
+		got, err := c.ListForeignAllocated()
+		if err != nil || len(got) != 0 {
+			t.Fatalf("expected no foreign claims, got %v (err %v)", got, err)
+		}
+	})
+
+	t.Run("allocation cleared forgets the foreign entry", func(t *testing.T) {
+		c := newTestClaimTracker()
+		onClaimEvent(c, converter, newAllocatedClaim("uid-c"))
+		onClaimEvent(c, converter, &resourceapi.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "claim", UID: types.UID("uid-c")},
+		})
+
+		got, err := c.ListForeignAllocated()
+		if err != nil || len(got) != 0 {
+			t.Fatalf("expected no foreign claims after allocation was cleared, got %v (err %v)", got, err)
+		}
+	})
+
+	t.Run("delete forgets the foreign entry, including through a tombstone", func(t *testing.T) {
+		c := newTestClaimTracker()
+		claim := newAllocatedClaim("uid-d")
+		onClaimEvent(c, converter, claim)
+
+		onClaimDelete(c, converter, cache.DeletedFinalStateUnknown{Key: "ns/claim", Obj: claim})
+
+		got, err := c.ListForeignAllocated()
+		if err != nil || len(got) != 0 {
+			t.Fatalf("expected no foreign claims after delete, got %v (err %v)", got, err)
+		}
+	})
+}
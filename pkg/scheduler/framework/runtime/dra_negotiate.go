@@ -0,0 +1,42 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtime
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/kubernetes/pkg/scheduler/framework/runtime/dra"
+)
+
+// resourceGroupName is the API group both DRA versions share; only the
+// version served under it differs.
+const resourceGroupName = "resource.k8s.io"
+
+// NegotiateAPIVersion asks the API server which DRA API version it serves
+// and returns the neutral dra.APIVersion DraManager should be constructed
+// with. v1alpha3 is preferred; v1alpha2 is used as a fallback for clusters
+// that have not upgraded yet.
+func NegotiateAPIVersion(discoveryClient discovery.DiscoveryInterface) (dra.APIVersion, error) {
+	for _, candidate := range []dra.APIVersion{dra.APIVersionV1alpha3, dra.APIVersionV1alpha2} {
+		_, err := discoveryClient.ServerResourcesForGroupVersion(resourceGroupName + "/" + string(candidate))
+		if err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("server does not serve any known DRA API version (tried %s and %s)", dra.APIVersionV1alpha3, dra.APIVersionV1alpha2)
+}